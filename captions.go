@@ -0,0 +1,167 @@
+package youtube
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CaptionTrack is a single timed-text track advertised by a video's
+// playerCaptionsTracklistRenderer.
+type CaptionTrack struct {
+	LanguageCode string
+	Name         string
+	Kind         string // "asr" for auto-generated, "" for manually authored
+	BaseURL      string
+}
+
+// Cue is a single caption line with its display window.
+type Cue struct {
+	Start    time.Duration
+	Duration time.Duration
+	Text     string
+}
+
+// Cues is a sequence of Cue, in display order.
+type Cues []Cue
+
+// captionTrackJSON mirrors one entry of
+// captions.playerCaptionsTracklistRenderer.captionTracks.
+type captionTrackJSON struct {
+	BaseURL string `json:"baseUrl"`
+	Name    struct {
+		SimpleText string `json:"simpleText"`
+	} `json:"name"`
+	LanguageCode string `json:"languageCode"`
+	Kind         string `json:"kind"`
+}
+
+func (c captionTrackJSON) toCaptionTrack() CaptionTrack {
+	return CaptionTrack{
+		LanguageCode: c.LanguageCode,
+		Name:         c.Name.SimpleText,
+		Kind:         c.Kind,
+		BaseURL:      c.BaseURL,
+	}
+}
+
+// Fetch downloads and parses this track's timed-text XML into a sequence
+// of cues.
+func (t CaptionTrack) Fetch(ctx context.Context) (Cues, error) {
+	return t.fetch(ctx, "")
+}
+
+// FetchTranslated downloads this track auto-translated into langCode
+// (e.g. "es"), the same way YouTube's own "Auto-translate" caption menu
+// does.
+func (t CaptionTrack) FetchTranslated(ctx context.Context, langCode string) (Cues, error) {
+	return t.fetch(ctx, langCode)
+}
+
+func (t CaptionTrack) fetch(ctx context.Context, tlang string) (Cues, error) {
+	url := t.BaseURL
+	if tlang != "" {
+		url += "&tlang=" + tlang
+	}
+
+	resp, err := httpClient().GetWithContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("fetching captions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading captions: %w", err)
+	}
+
+	return parseTimedText(body)
+}
+
+// timedText mirrors YouTube's timedtext XML format:
+// <transcript><text start="1.2" dur="3.4">Hello</text>...</transcript>
+type timedText struct {
+	Texts []struct {
+		Start string `xml:"start,attr"`
+		Dur   string `xml:"dur,attr"`
+		Text  string `xml:",chardata"`
+	} `xml:"text"`
+}
+
+func parseTimedText(body []byte) (Cues, error) {
+	var doc timedText
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("%w: parsing timedtext xml: %v", ErrSchemaChanged, err)
+	}
+
+	cues := make(Cues, 0, len(doc.Texts))
+	for _, t := range doc.Texts {
+		start, _ := strconv.ParseFloat(t.Start, 64)
+		dur, _ := strconv.ParseFloat(t.Dur, 64)
+		cues = append(cues, Cue{
+			Start:    time.Duration(start * float64(time.Second)),
+			Duration: time.Duration(dur * float64(time.Second)),
+			Text:     decodeTimedTextEntities(t.Text),
+		})
+	}
+	return cues, nil
+}
+
+func decodeTimedTextEntities(s string) string {
+	r := strings.NewReplacer(
+		"&amp;", "&",
+		"&#39;", "'",
+		"&quot;", `"`,
+	)
+	return r.Replace(s)
+}
+
+// ToSRT writes cues to w in SubRip (.srt) format.
+func (cues Cues) ToSRT(w io.Writer) error {
+	for i, c := range cues {
+		if _, err := fmt.Fprintf(w, "%d\n%s --> %s\n%s\n\n",
+			i+1, srtTimestamp(c.Start), srtTimestamp(c.Start+c.Duration), c.Text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ToVTT writes cues to w in WebVTT (.vtt) format.
+func (cues Cues) ToVTT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "WEBVTT"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+	for _, c := range cues {
+		if _, err := fmt.Fprintf(w, "%s --> %s\n%s\n\n",
+			vttTimestamp(c.Start), vttTimestamp(c.Start+c.Duration), c.Text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func srtTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	h := ms / 3600000
+	m := (ms % 3600000) / 60000
+	s := (ms % 60000) / 1000
+	msRemainder := ms % 1000
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", h, m, s, msRemainder)
+}
+
+func vttTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	h := ms / 3600000
+	m := (ms % 3600000) / 60000
+	s := (ms % 60000) / 1000
+	msRemainder := ms % 1000
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", h, m, s, msRemainder)
+}
@@ -0,0 +1,22 @@
+package youtube
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSrtTimestamp(t *testing.T) {
+	cases := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "00:00:00,000"},
+		{1500 * time.Millisecond, "00:00:01,500"},
+		{time.Hour + 2*time.Minute + 3*time.Second + 4*time.Millisecond, "01:02:03,004"},
+	}
+	for _, c := range cases {
+		if got := srtTimestamp(c.d); got != c.want {
+			t.Errorf("srtTimestamp(%v) = %q, want %q", c.d, got, c.want)
+		}
+	}
+}
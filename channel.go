@@ -0,0 +1,282 @@
+package youtube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const browseURL = "https://www.youtube.com/youtubei/v1/browse?key=%s"
+
+var ytcfgRegex = regexp.MustCompile(`ytcfg\.set\((\{.*?\})\);`)
+
+// Channel is the result of enumerating every video on a channel's, user's,
+// or "/c/" handle's videos tab, following continuation tokens until
+// YouTube stops returning more pages.
+type Channel struct {
+	ID     string
+	Videos []*PlaylistEntry
+}
+
+// ytcfg mirrors the subset of the object passed to ytcfg.set(...) on a
+// channel page that's needed to drive the browse continuation API.
+type ytcfg struct {
+	InnertubeAPIKey  string `json:"INNERTUBE_API_KEY"`
+	InnertubeContext struct {
+		Client struct {
+			ClientName    string `json:"clientName"`
+			ClientVersion string `json:"clientVersion"`
+			HL            string `json:"hl"`
+			GL            string `json:"gl"`
+		} `json:"client"`
+	} `json:"INNERTUBE_CONTEXT"`
+}
+
+// GetChannelVideos fetches every video listed under urlOrID's "/videos" tab
+// (a "/c/<name>", "/channel/<id>", or "/user/<name>" URL, or a bare
+// channel ID), paginating via the browse continuation API until exhausted.
+func GetChannelVideos(urlOrID string) (*Channel, error) {
+	channelID, cfg, vids, continuation, err := fetchChannelFirstPage(urlOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := &Channel{ID: channelID, Videos: vids}
+	for continuation != "" {
+		vids, next, err := fetchChannelContinuation(cfg, continuation)
+		if err != nil {
+			return ch, err
+		}
+		ch.Videos = append(ch.Videos, vids...)
+		continuation = next
+	}
+	return ch, nil
+}
+
+// IterateChannelVideos streams every video on urlOrID's videos tab,
+// fetching one continuation page at a time so the caller never has to hold
+// the whole channel in memory. The channel is closed once pagination is
+// exhausted or ctx is canceled.
+func IterateChannelVideos(ctx context.Context, urlOrID string) <-chan *PlaylistEntry {
+	out := make(chan *PlaylistEntry)
+	go func() {
+		defer close(out)
+		channelID, cfg, vids, continuation, err := fetchChannelFirstPage(urlOrID)
+		if err != nil {
+			logger().Printf("fetching channel first page: %v", err)
+			return
+		}
+		_ = channelID
+
+		for _, v := range vids {
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		for continuation != "" {
+			vids, next, err := fetchChannelContinuation(cfg, continuation)
+			if err != nil {
+				logger().Printf("fetching channel continuation: %v", err)
+				return
+			}
+			for _, v := range vids {
+				select {
+				case out <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+			continuation = next
+		}
+	}()
+	return out
+}
+
+func fetchChannelFirstPage(urlOrID string) (string, *ytcfg, []*PlaylistEntry, string, error) {
+	if !strings.Contains(urlOrID, "/") {
+		urlOrID = fmt.Sprintf("https://www.youtube.com/channel/%s/videos", urlOrID)
+	}
+
+	channelID, err := getVideoTypeID(urlOrID)
+	if err != nil {
+		return "", nil, nil, "", err
+	}
+
+	videosURL, err := getVideoType(urlOrID)
+	if err != nil {
+		return "", nil, nil, "", err
+	}
+
+	resp, err := httpClient().Get(videosURL)
+	if err != nil {
+		return "", nil, nil, "", fmt.Errorf("fetching channel page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	page, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, nil, "", fmt.Errorf("reading channel page: %w", err)
+	}
+
+	cfg, err := extractYtcfg(page)
+	if err != nil {
+		return "", nil, nil, "", err
+	}
+
+	data, err := extractPlaylistJSON(bytes.NewReader(page))
+	if err != nil {
+		return "", cfg, nil, "", fmt.Errorf("extracting channel JSON: %w", err)
+	}
+
+	p := &Playlist{ID: channelID}
+	if err := p.UnmarshalJSON(data); err != nil {
+		return "", cfg, nil, "", err
+	}
+
+	continuation, _ := findContinuationToken(data)
+	return p.ID, cfg, p.Videos, continuation, nil
+}
+
+// extractYtcfg parses the object passed to ytcfg.set(...) on the initial
+// channel page, which carries the INNERTUBE_API_KEY and INNERTUBE_CONTEXT
+// needed to POST subsequent browse continuations.
+func extractYtcfg(page []byte) (*ytcfg, error) {
+	m := ytcfgRegex.FindSubmatch(page)
+	if m == nil {
+		return nil, fmt.Errorf("%w: could not locate ytcfg.set(...) on channel page", ErrSchemaChanged)
+	}
+	var cfg ytcfg
+	if err := json.Unmarshal(m[1], &cfg); err != nil {
+		return nil, fmt.Errorf("%w: parsing ytcfg: %v", ErrSchemaChanged, err)
+	}
+	if cfg.InnertubeAPIKey == "" {
+		return nil, fmt.Errorf("%w: ytcfg missing INNERTUBE_API_KEY", ErrSchemaChanged)
+	}
+	return &cfg, nil
+}
+
+// findContinuationToken looks for the trailing
+// continuationItemRenderer.continuationEndpoint.continuationCommand.token
+// in a gridRenderer's items. Other renderers are not searched.
+func findContinuationToken(data []byte) (string, error) {
+	var probe struct {
+		Contents struct {
+			TwoColumnBrowseResultsRenderer struct {
+				Tabs []struct {
+					TabRenderer struct {
+						Content struct {
+							SectionListRenderer struct {
+								Contents []struct {
+									ItemSectionRenderer struct {
+										Contents []struct {
+											GridRenderer struct {
+												Items []continuationItem `json:"items"`
+											} `json:"gridRenderer"`
+										} `json:"contents"`
+									} `json:"itemSectionRenderer"`
+								} `json:"contents"`
+							} `json:"sectionListRenderer"`
+						} `json:"content"`
+					} `json:"tabRenderer"`
+				} `json:"tabs"`
+			} `json:"twoColumnBrowseResultsRenderer"`
+		} `json:"contents"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrSchemaChanged, err)
+	}
+
+	for _, tab := range probe.Contents.TwoColumnBrowseResultsRenderer.Tabs {
+		for _, section := range tab.TabRenderer.Content.SectionListRenderer.Contents {
+			for _, item := range section.ItemSectionRenderer.Contents {
+				items := item.GridRenderer.Items
+				if len(items) == 0 {
+					continue
+				}
+				if tok := items[len(items)-1].token(); tok != "" {
+					return tok, nil
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
+type continuationItem struct {
+	ContinuationItemRenderer struct {
+		ContinuationEndpoint struct {
+			ContinuationCommand struct {
+				Token string `json:"token"`
+			} `json:"continuationCommand"`
+		} `json:"continuationEndpoint"`
+	} `json:"continuationItemRenderer"`
+}
+
+func (c continuationItem) token() string {
+	return c.ContinuationItemRenderer.ContinuationEndpoint.ContinuationCommand.Token
+}
+
+// browseResponse mirrors the subset of a youtubei/v1/browse continuation
+// response needed to keep paginating.
+type browseResponse struct {
+	OnResponseReceivedActions []struct {
+		AppendContinuationItemsAction struct {
+			ContinuationItems []json.RawMessage `json:"continuationItems"`
+		} `json:"appendContinuationItemsAction"`
+	} `json:"onResponseReceivedActions"`
+}
+
+func fetchChannelContinuation(cfg *ytcfg, token string) ([]*PlaylistEntry, string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"context":      cfg.InnertubeContext,
+		"continuation": token,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := httpClient().Post(fmt.Sprintf(browseURL, cfg.InnertubeAPIKey), "application/json", body)
+	if err != nil {
+		return nil, "", fmt.Errorf("posting browse continuation: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var br browseResponse
+	if err := json.NewDecoder(resp.Body).Decode(&br); err != nil {
+		return nil, "", fmt.Errorf("%w: decoding browse response: %v", ErrSchemaChanged, err)
+	}
+
+	var (
+		entries   []*PlaylistEntry
+		nextToken string
+	)
+	for _, action := range br.OnResponseReceivedActions {
+		for _, raw := range action.AppendContinuationItemsAction.ContinuationItems {
+			var vje videosJSONExtractor
+			if err := json.Unmarshal(raw, &vje); err == nil && (vje.Renderer != nil || vje.ChannelRenderer != nil) {
+				entry, err := vje.PlaylistEntry()
+				if err != nil {
+					logger().Printf("skipping malformed continuation entry: %v", err)
+					continue
+				}
+				entries = append(entries, entry)
+				continue
+			}
+			var ci continuationItem
+			if err := json.Unmarshal(raw, &ci); err == nil {
+				if tok := ci.token(); tok != "" {
+					nextToken = tok
+				}
+			}
+		}
+	}
+
+	return entries, nextToken, nil
+}
@@ -0,0 +1,330 @@
+package youtube
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ProxySelection picks the next proxy to use out of a Client's pool.
+type ProxySelection int
+
+const (
+	// ProxyRoundRobin cycles through the pool in order.
+	ProxyRoundRobin ProxySelection = iota
+	// ProxyLeastRecentlyUsed picks whichever proxy was used longest ago.
+	ProxyLeastRecentlyUsed
+)
+
+var defaultUserAgents = []string{
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Safari/605.1.15",
+	"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36",
+}
+
+// ClientOptions configures a Client.
+type ClientOptions struct {
+	// Proxies is a pool of SOCKS5 or HTTP proxy URLs (e.g.
+	// "socks5://127.0.0.1:1080"). Empty means no proxy is used.
+	Proxies []string
+	// ProxySelection controls how the next proxy in Proxies is chosen.
+	// Defaults to ProxyRoundRobin.
+	ProxySelection ProxySelection
+	// QPS is the maximum number of requests per second issued to any
+	// single host. Zero disables rate limiting.
+	QPS float64
+	// UserAgents rotates between entries on every request. Defaults to
+	// defaultUserAgents when empty.
+	UserAgents []string
+	// MaxRetries bounds how many times a request is retried after a 429
+	// response by switching to the next proxy. Defaults to 3.
+	MaxRetries int
+}
+
+// Client wraps http.Client with the pooling, rate-limiting, and
+// interstitial-handling behavior large batch jobs (e.g. syncing an entire
+// channel) need to avoid IP bans.
+type Client struct {
+	opts       ClientOptions
+	transports []*http.Client
+	next       uint32
+	lastUsed   []time.Time
+	mu         sync.Mutex
+
+	limiters   sync.Map // host string -> *rate.Limiter
+	userAgents []string
+	uaNext     uint32
+}
+
+// NewClient builds a Client from opts. A zero-value ClientOptions yields a
+// Client with no proxy and no rate limit, which still rotates user agents,
+// keeps a cookie jar seeded with the consent cookie, and retries failed
+// requests up to 3 times.
+func NewClient(opts ClientOptions) *Client {
+	c := &Client{opts: opts}
+
+	c.userAgents = opts.UserAgents
+	if len(c.userAgents) == 0 {
+		c.userAgents = defaultUserAgents
+	}
+
+	if len(opts.Proxies) == 0 {
+		c.transports = []*http.Client{{Jar: newConsentJar()}}
+	} else {
+		for _, p := range opts.Proxies {
+			c.transports = append(c.transports, newProxiedClient(p))
+		}
+	}
+	c.lastUsed = make([]time.Time, len(c.transports))
+
+	return c
+}
+
+func newConsentJar() *cookiejar.Jar {
+	jar, _ := cookiejar.New(nil)
+	return jar
+}
+
+func newProxiedClient(proxyURL string) *http.Client {
+	client := &http.Client{Jar: newConsentJar()}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		logger().Printf("ignoring invalid proxy %q: %v", proxyURL, err)
+		return client
+	}
+	client.Transport = &http.Transport{Proxy: http.ProxyURL(u)}
+	return client
+}
+
+func (c *Client) pickTransport() (int, *http.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.transports) == 1 {
+		return 0, c.transports[0]
+	}
+
+	switch c.opts.ProxySelection {
+	case ProxyLeastRecentlyUsed:
+		idx := 0
+		for i, t := range c.lastUsed {
+			if t.Before(c.lastUsed[idx]) {
+				idx = i
+			}
+		}
+		c.lastUsed[idx] = time.Now()
+		return idx, c.transports[idx]
+	default:
+		idx := int(atomic.AddUint32(&c.next, 1)-1) % len(c.transports)
+		c.lastUsed[idx] = time.Now()
+		return idx, c.transports[idx]
+	}
+}
+
+func (c *Client) nextUserAgent() string {
+	idx := int(atomic.AddUint32(&c.uaNext, 1)-1) % len(c.userAgents)
+	return c.userAgents[idx]
+}
+
+func (c *Client) limiterFor(host string) *rate.Limiter {
+	if c.opts.QPS <= 0 {
+		return nil
+	}
+	v, _ := c.limiters.LoadOrStore(host, rate.NewLimiter(rate.Limit(c.opts.QPS), 1))
+	return v.(*rate.Limiter)
+}
+
+func (c *Client) maxRetries() int {
+	if c.opts.MaxRetries <= 0 {
+		return 3
+	}
+	return c.opts.MaxRetries
+}
+
+// Get issues a GET request, applying per-host rate limiting, user-agent
+// rotation, and the consent cookie YouTube expects before it will serve a
+// page to a new client. On a 429 response it switches to the next proxy in
+// the pool and retries, up to ClientOptions.MaxRetries times.
+func (c *Client) Get(rawURL string) (*http.Response, error) {
+	return c.GetWithContext(context.Background(), rawURL)
+}
+
+// GetWithContext is Get with a caller-supplied context, for callers (such
+// as CaptionTrack.Fetch) that need to be cancelable mid-request.
+func (c *Client) GetWithContext(ctx context.Context, rawURL string) (*http.Response, error) {
+	return c.do(ctx, rawURL, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	})
+}
+
+// Post issues a POST request with the given content type and body,
+// applying the same per-host rate limiting, user-agent rotation, and
+// 429/proxy-rotation retry behavior as Get.
+func (c *Client) Post(rawURL, contentType string, body []byte) (*http.Response, error) {
+	ctx := context.Background()
+	return c.do(ctx, rawURL, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", contentType)
+		return req, nil
+	})
+}
+
+// do runs newReq through the rate limiter, a selected transport, and the
+// 429 retry loop. newReq is called again on every retry since an
+// http.Request's body can only be read once.
+func (c *Client) do(ctx context.Context, rawURL string, newReq func() (*http.Request, error)) (*http.Response, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries(); attempt++ {
+		if limiter := c.limiterFor(u.Host); limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		_, transport := c.pickTransport()
+		c.ensureConsentCookie(transport, u)
+
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", c.nextUserAgent())
+
+		resp, err := transport.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			resp.Body.Close()
+			lastErr = fmt.Errorf("got 429 from %s", u.Host)
+			continue
+		}
+
+		if host := consentRedirectHost(resp); host != "" {
+			resp.Body.Close()
+			logger().Printf("hit consent interstitial at %s; retrying with consent cookie set", host)
+			lastErr = fmt.Errorf("%w: redirected to %s", ErrConsentRequired, host)
+			continue
+		}
+
+		resp, ageRestricted, err := peekAgeRestricted(resp)
+		if err != nil {
+			resp.Body.Close()
+			lastErr = err
+			continue
+		}
+		if ageRestricted {
+			logger().Printf("%v: %s", ErrAgeRestricted, u)
+		}
+
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("youtube: giving up after %d attempts: %w", c.maxRetries()+1, lastErr)
+}
+
+// consentRedirectHost returns the host of resp's final URL if the request
+// was redirected to YouTube's EU consent interstitial, or "" otherwise.
+// http.Client.Do follows redirects itself, so resp.Request already carries
+// the last URL actually fetched.
+func consentRedirectHost(resp *http.Response) string {
+	if resp.Request == nil || resp.Request.URL == nil {
+		return ""
+	}
+	if strings.Contains(resp.Request.URL.Host, "consent.youtube.com") {
+		return resp.Request.URL.Host
+	}
+	return ""
+}
+
+// ageRestrictedMarker is present in a watch page's playerResponse JSON
+// when the video is age-gated and the request isn't authenticated.
+var ageRestrictedMarker = []byte(`"reason":"Sign in to confirm your age"`)
+
+// peekAgeRestricted reads up to 8KB of resp.Body looking for
+// ageRestrictedMarker, then hands back a response whose Body still yields
+// every byte (the peeked prefix followed by the rest of the stream) so the
+// caller sees the full, unconsumed body.
+func peekAgeRestricted(resp *http.Response) (*http.Response, bool, error) {
+	const peekSize = 8192
+	buf := make([]byte, peekSize)
+	n, err := io.ReadFull(resp.Body, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return resp, false, err
+	}
+	buf = buf[:n]
+	resp.Body = &peekedBody{Reader: io.MultiReader(bytes.NewReader(buf), resp.Body), closer: resp.Body}
+	return resp, bytes.Contains(buf, ageRestrictedMarker), nil
+}
+
+// peekedBody re-attaches the original Close behind an io.Reader that was
+// rebuilt from a buffered peek plus the remainder of the stream.
+type peekedBody struct {
+	io.Reader
+	closer io.Closer
+}
+
+func (p *peekedBody) Close() error { return p.closer.Close() }
+
+// ensureConsentCookie pre-seeds the "CONSENT" cookie YouTube's EU
+// interstitial looks for, so a fresh cookie jar doesn't get redirected to
+// consent.youtube.com on the very first request.
+func (c *Client) ensureConsentCookie(transport *http.Client, u *url.URL) {
+	if transport.Jar == nil || !strings.HasSuffix(u.Host, "youtube.com") {
+		return
+	}
+	root := &url.URL{Scheme: u.Scheme, Host: u.Host}
+	for _, existing := range transport.Jar.Cookies(root) {
+		if existing.Name == "CONSENT" {
+			return
+		}
+	}
+	transport.Jar.SetCookies(root, []*http.Cookie{{
+		Name:  "CONSENT",
+		Value: fmt.Sprintf("YES+cb.%d", time.Now().Year()*10000+rand.Intn(9999)),
+	}})
+}
+
+var (
+	defaultClientMu sync.RWMutex
+	defaultClient   = NewClient(ClientOptions{})
+)
+
+// SetClient installs c as the package-wide HTTP client used by
+// GetPlaylistInfo, GetVideoInfo, and their manifest/caption fetches.
+// Passing nil restores the default client.
+func SetClient(c *Client) {
+	defaultClientMu.Lock()
+	defer defaultClientMu.Unlock()
+	if c == nil {
+		c = NewClient(ClientOptions{})
+	}
+	defaultClient = c
+}
+
+func httpClient() *Client {
+	defaultClientMu.RLock()
+	defer defaultClientMu.RUnlock()
+	return defaultClient
+}
@@ -0,0 +1,143 @@
+package youtube
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// decipherOps is the ordered list of transformations a player's signature
+// decipher function applies to a scrambled `s` parameter.
+type decipherOps struct {
+	steps []decipherStep
+}
+
+// decipherStep is a single splice/swap/reverse operation extracted from the
+// player JS helper object referenced by the decipher function.
+type decipherStep struct {
+	kind decipherKind
+	arg  int
+}
+
+type decipherKind int
+
+const (
+	opReverse decipherKind = iota
+	opSplice
+	opSwap
+)
+
+func (ops *decipherOps) apply(sig string) string {
+	s := []byte(sig)
+	for _, step := range ops.steps {
+		switch step.kind {
+		case opReverse:
+			for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+				s[i], s[j] = s[j], s[i]
+			}
+		case opSplice:
+			if step.arg < len(s) {
+				s = s[step.arg:]
+			}
+		case opSwap:
+			if len(s) > 0 {
+				pos := step.arg % len(s)
+				s[0], s[pos] = s[pos], s[0]
+			}
+		}
+	}
+	return string(s)
+}
+
+var (
+	// decipherFuncNameRegex finds the name of the function the player calls
+	// to compute the final signature, e.g.:
+	//   c&&d.set(a,encodeURIComponent(XyZ(d.get(a))))
+	decipherFuncNameRegex = regexp.MustCompile(`\bc\s*&&\s*d\.set\([^,]+,\s*(?:encodeURIComponent\s*\()?([a-zA-Z0-9$]+)\(`)
+
+	// decipherHelperObjRegex matches a helper call on the scrambled array
+	// `a`, with the numeric argument splice/swap take optional since
+	// reverse is called as e.g. "Mt.sH(a)" with no second argument at all.
+	decipherHelperObjRegex = regexp.MustCompile(`([a-zA-Z0-9$]+)\.([a-zA-Z0-9$]+)\(a(?:,(\d+))?\)`)
+)
+
+// parseDecipherOps extracts the decipher function from player JS source and
+// translates its body into a sequence of decipherStep values by resolving
+// each call into the underlying helper object's splice/swap/reverse
+// implementation.
+func parseDecipherOps(playerJS string) (*decipherOps, error) {
+	nameMatch := decipherFuncNameRegex.FindStringSubmatch(playerJS)
+	if nameMatch == nil {
+		return nil, fmt.Errorf("%w: could not locate decipher function name", ErrSignatureCipher)
+	}
+	fnName := regexp.QuoteMeta(nameMatch[1])
+
+	fnBodyRegex := regexp.MustCompile(fnName + `=function\(a\)\{(.*?)\}`)
+	fnMatch := fnBodyRegex.FindStringSubmatch(playerJS)
+	if fnMatch == nil {
+		return nil, fmt.Errorf("%w: could not locate decipher function body", ErrSignatureCipher)
+	}
+	body := fnMatch[1]
+
+	helperCalls := decipherHelperObjRegex.FindAllStringSubmatch(body, -1)
+	if len(helperCalls) == 0 {
+		return nil, fmt.Errorf("%w: decipher function body has no helper calls", ErrSignatureCipher)
+	}
+	helperName := regexp.QuoteMeta(helperCalls[0][1])
+
+	helperObjRegex := regexp.MustCompile(`var\s+` + helperName + `=\{(.*?)\}\s*;`)
+	helperMatch := helperObjRegex.FindStringSubmatch(playerJS)
+	if helperMatch == nil {
+		return nil, fmt.Errorf("%w: could not locate helper object %s", ErrSignatureCipher, helperCalls[0][1])
+	}
+
+	kindByMethod := classifyHelperMethods(helperMatch[1])
+
+	var ops decipherOps
+	for _, stmt := range strings.Split(body, ";") {
+		m := decipherHelperObjRegex.FindStringSubmatch(stmt)
+		if m == nil {
+			continue
+		}
+		method := m[2]
+		var arg int
+		if m[3] != "" {
+			arg, _ = strconv.Atoi(m[3])
+		}
+
+		kind, ok := kindByMethod[method]
+		if !ok {
+			continue
+		}
+		ops.steps = append(ops.steps, decipherStep{kind: kind, arg: arg})
+	}
+
+	if len(ops.steps) == 0 {
+		return nil, fmt.Errorf("%w: resolved zero decipher steps", ErrSignatureCipher)
+	}
+	return &ops, nil
+}
+
+// classifyHelperMethods inspects each method of the helper object literal
+// and determines whether it reverses the array in place, splices off its
+// front, or swaps the first element with one at a given index.
+func classifyHelperMethods(helperBody string) map[string]decipherKind {
+	kinds := make(map[string]decipherKind)
+	for _, decl := range strings.Split(helperBody, "},") {
+		parts := strings.SplitN(decl, ":function", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		name := strings.TrimSpace(parts[0])
+		switch {
+		case strings.Contains(parts[1], "reverse"):
+			kinds[name] = opReverse
+		case strings.Contains(parts[1], "splice"):
+			kinds[name] = opSplice
+		case strings.Contains(parts[1], "var c=a[0]") || strings.Contains(parts[1], "%b.length"):
+			kinds[name] = opSwap
+		}
+	}
+	return kinds
+}
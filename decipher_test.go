@@ -0,0 +1,44 @@
+package youtube
+
+import "testing"
+
+// syntheticPlayerJS is a minified player JS snippet shaped like a real one:
+// a helper object with a no-arg reverse, a splice, and a swap, called from a
+// decipher function that's in turn invoked from the c&&d.set(...) idiom.
+const syntheticPlayerJS = `var Mt={TU:function(a,b){a.splice(0,b)},sH:function(a){a.reverse()},JI:function(a,b){var c=a[0];a[0]=a[b%a.length];a[b%a.length]=c}};XyZ=function(a){a=a.split("");Mt.sH(a);Mt.TU(a,3);Mt.JI(a,5);return a.join("")};c&&d.set(a,encodeURIComponent(XyZ(d.get(a))))`
+
+func TestParseDecipherOps(t *testing.T) {
+	ops, err := parseDecipherOps(syntheticPlayerJS)
+	if err != nil {
+		t.Fatalf("parseDecipherOps: %v", err)
+	}
+	if len(ops.steps) != 3 {
+		t.Fatalf("got %d steps, want 3: %+v", len(ops.steps), ops.steps)
+	}
+
+	want := []decipherStep{
+		{kind: opReverse, arg: 0},
+		{kind: opSplice, arg: 3},
+		{kind: opSwap, arg: 5},
+	}
+	for i, w := range want {
+		if ops.steps[i] != w {
+			t.Errorf("step %d = %+v, want %+v", i, ops.steps[i], w)
+		}
+	}
+}
+
+func TestDecipherOpsApply(t *testing.T) {
+	ops, err := parseDecipherOps(syntheticPlayerJS)
+	if err != nil {
+		t.Fatalf("parseDecipherOps: %v", err)
+	}
+
+	// sig="abcdefgh": reverse -> "hgfedcba", splice(3) -> "edcba",
+	// swap(0, 5%5=0) -> unchanged -> "edcba".
+	got := ops.apply("abcdefgh")
+	want := "edcba"
+	if got != want {
+		t.Errorf("apply(%q) = %q, want %q", "abcdefgh", got, want)
+	}
+}
@@ -0,0 +1,291 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// defaultChunks is the number of concurrent Range requests Downloader uses
+// when the destination supports io.WriterAt.
+const defaultChunks = 4
+
+// Progress describes the state of an in-flight download.
+type Progress struct {
+	BytesDone  int64
+	BytesTotal int64
+	ETA        time.Duration
+}
+
+// Downloader fetches a Format's media URL, splitting it into N concurrent
+// HTTP Range requests when the destination supports random-access writes,
+// and persisting a sidecar .part.json state file so an interrupted download
+// can resume from the last completed offset.
+type Downloader struct {
+	// Client is the *http.Client used for range requests. Defaults to
+	// http.DefaultClient when nil.
+	Client *http.Client
+	// Chunks is the number of concurrent range requests. Defaults to
+	// defaultChunks when <= 0.
+	Chunks int
+	// MaxRetries is the number of retries per chunk on transient errors.
+	// Defaults to 5 when <= 0.
+	MaxRetries int
+	// Progress, when non-nil, is sent a Progress update after every
+	// completed chunk (and periodically by DownloadStream). The send does
+	// not block: an update is dropped if the channel cannot take it.
+	Progress chan Progress
+}
+
+// downloadState is the sidecar .part.json structure recording which byte
+// ranges have already been written to dst.
+type downloadState struct {
+	URL       string `json:"url"`
+	Total     int64  `json:"total"`
+	Done      []bool `json:"done"`
+	ChunkSize int64  `json:"chunkSize"`
+}
+
+func (d *Downloader) client() *http.Client {
+	if d.Client != nil {
+		return d.Client
+	}
+	return http.DefaultClient
+}
+
+func (d *Downloader) chunks() int {
+	if d.Chunks <= 0 {
+		return defaultChunks
+	}
+	return d.Chunks
+}
+
+func (d *Downloader) maxRetries() int {
+	if d.MaxRetries <= 0 {
+		return 5
+	}
+	return d.MaxRetries
+}
+
+// Download fetches format.URL into dst, splitting the transfer into
+// Downloader.Chunks parallel Range requests. It is resumable via a sidecar
+// state file (e.g. "<dst>.part.json") written at statePath and removed once
+// every chunk has completed. For a destination that is only an io.Writer,
+// use DownloadStream, which transfers a single, non-resumable stream.
+func (d *Downloader) Download(ctx context.Context, format Format, dst io.WriterAt, statePath string) error {
+	total := format.ContentLength
+	if total <= 0 {
+		return fmt.Errorf("download: format itag %d has unknown content length", format.Itag)
+	}
+
+	n := d.chunks()
+	chunkSize := int64(math.Ceil(float64(total) / float64(n)))
+
+	state, err := loadOrInitState(statePath, format.URL, total, chunkSize, n)
+	if err != nil {
+		return err
+	}
+
+	var (
+		mu        sync.Mutex
+		bytesDone int64
+		wg        sync.WaitGroup
+		errs      = make([]error, n)
+		start     = time.Now()
+	)
+
+	for i := 0; i < n; i++ {
+		if state.Done[i] {
+			mu.Lock()
+			bytesDone += chunkRange(i, chunkSize, total)
+			mu.Unlock()
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			lo := int64(i) * chunkSize
+			hi := lo + chunkSize - 1
+			if hi >= total {
+				hi = total - 1
+			}
+			n, err := d.downloadChunkWithRetry(ctx, format.URL, dst, lo, hi)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			mu.Lock()
+			bytesDone += n
+			state.Done[i] = true
+			_ = saveState(statePath, state)
+			done, totalN := bytesDone, total
+			mu.Unlock()
+			d.emitProgress(done, totalN, start)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return os.Remove(statePath)
+}
+
+// DownloadStream fetches format.URL into dst as a single, non-resumable
+// stream. Use this when dst does not support random-access writes (e.g. it
+// is a pipe or a network socket).
+func (d *Downloader) DownloadStream(ctx context.Context, format Format, dst io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, format.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := d.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download: unexpected status %s", resp.Status)
+	}
+
+	start := time.Now()
+	total := format.ContentLength
+	counter := &countingWriter{w: dst}
+	done := make(chan struct{})
+	if total > 0 {
+		go func() {
+			ticker := time.NewTicker(500 * time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					d.emitProgress(counter.n, total, start)
+				}
+			}
+		}()
+	}
+	_, err = io.Copy(counter, resp.Body)
+	close(done)
+	return err
+}
+
+func (d *Downloader) downloadChunkWithRetry(ctx context.Context, url string, dst io.WriterAt, lo, hi int64) (int64, error) {
+	var lastErr error
+	for attempt := 0; attempt <= d.maxRetries(); attempt++ {
+		if attempt > 0 {
+			backoff := time.Duration(math.Pow(2, float64(attempt))) * 100 * time.Millisecond
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+		n, err := d.downloadChunk(ctx, url, dst, lo, hi)
+		if err == nil {
+			return n, nil
+		}
+		lastErr = err
+	}
+	return 0, fmt.Errorf("download chunk [%d-%d]: %w", lo, hi, lastErr)
+}
+
+func (d *Downloader) downloadChunk(ctx context.Context, url string, dst io.WriterAt, lo, hi int64) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", lo, hi))
+
+	resp, err := d.client().Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// A 200 here means the server ignored our Range header and sent the
+	// whole body; writing that at lo would corrupt dst with overlapping,
+	// duplicated content, so only a genuine ranged response is acceptable.
+	if resp.StatusCode != http.StatusPartialContent {
+		return 0, fmt.Errorf("server returned %s instead of %d Partial Content (Range header may be unsupported; use DownloadStream instead)", resp.Status, http.StatusPartialContent)
+	}
+
+	buf, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := dst.WriteAt(buf, lo); err != nil {
+		return 0, err
+	}
+	return int64(len(buf)), nil
+}
+
+func (d *Downloader) emitProgress(done, total int64, start time.Time) {
+	if d.Progress == nil {
+		return
+	}
+	elapsed := time.Since(start)
+	var eta time.Duration
+	if done > 0 {
+		rate := float64(elapsed) / float64(done)
+		eta = time.Duration(rate * float64(total-done))
+	}
+	select {
+	case d.Progress <- Progress{BytesDone: done, BytesTotal: total, ETA: eta}:
+	default:
+	}
+}
+
+func chunkRange(i int, chunkSize, total int64) int64 {
+	lo := int64(i) * chunkSize
+	hi := lo + chunkSize
+	if hi > total {
+		hi = total
+	}
+	return hi - lo
+}
+
+func loadOrInitState(statePath, url string, total, chunkSize int64, n int) (*downloadState, error) {
+	if data, err := os.ReadFile(statePath); err == nil {
+		var state downloadState
+		if err := json.Unmarshal(data, &state); err == nil &&
+			state.URL == url && state.Total == total &&
+			state.ChunkSize == chunkSize && len(state.Done) == n {
+			return &state, nil
+		}
+	}
+	state := &downloadState{URL: url, Total: total, ChunkSize: chunkSize, Done: make([]bool, n)}
+	return state, saveState(statePath, state)
+}
+
+func saveState(statePath string, state *downloadState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(statePath, data, 0o644)
+}
+
+// countingWriter wraps an io.Writer and tracks the total number of bytes
+// written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
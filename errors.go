@@ -0,0 +1,32 @@
+package youtube
+
+import "errors"
+
+// Sentinel errors returned by this package. Callers should compare against
+// these with errors.Is rather than matching on error strings, since the
+// wrapped detail (via %w) varies by call site.
+var (
+	// ErrInvalidPlaylist is returned when a playlist URL or ID cannot be
+	// parsed.
+	ErrInvalidPlaylist = errors.New("youtube: invalid playlist url or id")
+	// ErrPlaylistEmpty is returned when a playlist (or channel videos) page parses
+	// successfully but contains no usable entries.
+	ErrPlaylistEmpty = errors.New("youtube: playlist has no videos")
+	// ErrParseDuration is returned when a video's duration cannot be
+	// parsed from its rendered form.
+	ErrParseDuration = errors.New("youtube: failed to parse video duration")
+	// ErrSchemaChanged is returned when YouTube's page or API response no
+	// longer matches the shape this package expects to scrape.
+	ErrSchemaChanged = errors.New("youtube: page schema no longer matches expected shape")
+	// ErrSignatureCipher is returned when a stream's signature cipher
+	// cannot be resolved into a playable URL.
+	ErrSignatureCipher = errors.New("youtube: failed to resolve signature cipher")
+	// ErrConsentRequired is returned when a request was redirected to
+	// YouTube's EU consent interstitial instead of the page it asked for.
+	ErrConsentRequired = errors.New("youtube: hit consent interstitial")
+	// ErrAgeRestricted is returned (informationally, via the Logger) when
+	// a watch page's playerResponse reports the video as age-gated and
+	// the request isn't authenticated. This package does not support
+	// logging in, so age-restricted videos cannot be resolved further.
+	ErrAgeRestricted = errors.New("youtube: video is age-restricted")
+)
@@ -0,0 +1,40 @@
+package youtube
+
+import "sync"
+
+// Logger receives diagnostic messages from the playlist, video, and
+// manifest scrapers -- things like a skipped malformed entry or a
+// signature that failed to resolve, which are worth surfacing but should
+// never abort the caller's request.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// noopLogger discards every message. It is the default Logger so that
+// importing this package is silent unless a caller opts in with
+// SetLogger.
+type noopLogger struct{}
+
+func (noopLogger) Printf(string, ...interface{}) {}
+
+var (
+	loggerMu  sync.RWMutex
+	pkgLogger Logger = noopLogger{}
+)
+
+// SetLogger installs l as the package-wide diagnostic logger. Passing nil
+// restores the default no-op logger.
+func SetLogger(l Logger) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+	if l == nil {
+		l = noopLogger{}
+	}
+	pkgLogger = l
+}
+
+func logger() Logger {
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+	return pkgLogger
+}
@@ -0,0 +1,261 @@
+package youtube
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DashFormat is a single Representation parsed out of a DASH MPD manifest.
+// Unlike the progressive/adaptive formats in Video.Formats, DASH
+// representations are delivered as a sequence of byte-range or templated
+// segments rather than a single URL.
+type DashFormat struct {
+	Itag         int
+	Codecs       string
+	Bandwidth    int
+	Width        int
+	Height       int
+	Timescale    int
+	InitURL      string
+	SegmentURLs_ []string
+}
+
+// SegmentURLs returns the ordered list of segment URLs a caller must fetch
+// (in order, after InitURL) to reassemble this representation.
+func (d DashFormat) SegmentURLs() []string {
+	return d.SegmentURLs_
+}
+
+// HlsVariant is a single #EXT-X-STREAM-INF entry from an HLS master
+// playlist, resolved down to its own media-segment playlist.
+type HlsVariant struct {
+	Bandwidth   int
+	Resolution  string
+	Codecs      string
+	PlaylistURL string
+	Segments    []string
+}
+
+// SegmentURLs returns the ordered list of .ts (or fMP4) media segment URLs
+// that make up this variant.
+func (h HlsVariant) SegmentURLs() []string {
+	return h.Segments
+}
+
+// mpd mirrors the subset of a DASH MPD document needed to build
+// DashFormat entries.
+type mpd struct {
+	XMLName xml.Name `xml:"MPD"`
+	Periods []struct {
+		AdaptationSets []struct {
+			Representations []struct {
+				ID              string `xml:"id,attr"`
+				Codecs          string `xml:"codecs,attr"`
+				Bandwidth       int    `xml:"bandwidth,attr"`
+				Width           int    `xml:"width,attr"`
+				Height          int    `xml:"height,attr"`
+				SegmentTemplate *struct {
+					Timescale       int    `xml:"timescale,attr"`
+					Initialization  string `xml:"initialization,attr"`
+					Media           string `xml:"media,attr"`
+					SegmentTimeline *struct {
+						S []struct {
+							T int64 `xml:"t,attr"`
+							D int64 `xml:"d,attr"`
+							R int   `xml:"r,attr"`
+						} `xml:"S"`
+					} `xml:"SegmentTimeline"`
+				} `xml:"SegmentTemplate"`
+				BaseURL string `xml:"BaseURL"`
+			} `xml:"Representation"`
+		} `xml:"AdaptationSet"`
+	} `xml:"Period"`
+}
+
+// fetchDashFormats downloads and parses the DASH MPD manifest at
+// dashManifestURL into a list of DashFormat, one per Representation.
+func fetchDashFormats(dashManifestURL string) ([]DashFormat, error) {
+	resp, err := httpClient().Get(dashManifestURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetching dash manifest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading dash manifest: %w", err)
+	}
+	return parseDashManifest(body)
+}
+
+func parseDashManifest(body []byte) ([]DashFormat, error) {
+	var doc mpd
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("%w: parsing dash manifest: %v", ErrSchemaChanged, err)
+	}
+
+	var formats []DashFormat
+	for _, period := range doc.Periods {
+		for _, as := range period.AdaptationSets {
+			for _, rep := range as.Representations {
+				itag, _ := strconv.Atoi(rep.ID)
+				f := DashFormat{
+					Itag:      itag,
+					Codecs:    rep.Codecs,
+					Bandwidth: rep.Bandwidth,
+					Width:     rep.Width,
+					Height:    rep.Height,
+				}
+				if st := rep.SegmentTemplate; st != nil {
+					f.Timescale = st.Timescale
+					f.InitURL = expandSegmentTemplate(st.Initialization, itag, 0, 0)
+					if st.SegmentTimeline != nil {
+						segNum := 1
+						var segTime int64
+						for _, s := range st.SegmentTimeline.S {
+							if s.T != 0 {
+								segTime = s.T
+							}
+							repeats := s.R + 1
+							for i := 0; i < repeats; i++ {
+								f.SegmentURLs_ = append(f.SegmentURLs_, expandSegmentTemplate(st.Media, itag, segNum, segTime))
+								segNum++
+								segTime += s.D
+							}
+						}
+					}
+				} else if rep.BaseURL != "" {
+					f.SegmentURLs_ = []string{rep.BaseURL}
+				}
+				formats = append(formats, f)
+			}
+		}
+	}
+	return formats, nil
+}
+
+func expandSegmentTemplate(tmpl string, itag, number int, segTime int64) string {
+	r := strings.NewReplacer(
+		"$RepresentationID$", strconv.Itoa(itag),
+		"$Number$", strconv.Itoa(number),
+		"$Time$", strconv.FormatInt(segTime, 10),
+	)
+	return r.Replace(tmpl)
+}
+
+// fetchHlsVariants downloads the HLS master playlist at hlsManifestURL and
+// then, for each #EXT-X-STREAM-INF variant, downloads its media playlist to
+// collect the segment list.
+func fetchHlsVariants(hlsManifestURL string) ([]HlsVariant, error) {
+	resp, err := httpClient().Get(hlsManifestURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetching hls master playlist: %w", err)
+	}
+	defer resp.Body.Close()
+
+	variants, err := parseHlsMaster(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range variants {
+		segs, err := fetchHlsSegments(variants[i].PlaylistURL)
+		if err != nil {
+			logger().Printf("fetching hls segments for %s: %v", variants[i].PlaylistURL, err)
+			continue
+		}
+		variants[i].Segments = segs
+	}
+	return variants, nil
+}
+
+// parseHlsMaster parses a #EXT-X-STREAM-INF master playlist, pairing each
+// attribute line with the URL line that follows it.
+func parseHlsMaster(r io.Reader) ([]HlsVariant, error) {
+	var variants []HlsVariant
+	scanner := bufio.NewScanner(r)
+	var pending *HlsVariant
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "#EXT-X-STREAM-INF:"):
+			v := HlsVariant{}
+			attrs := strings.TrimPrefix(line, "#EXT-X-STREAM-INF:")
+			for _, attr := range splitHlsAttrs(attrs) {
+				kv := strings.SplitN(attr, "=", 2)
+				if len(kv) != 2 {
+					continue
+				}
+				switch kv[0] {
+				case "BANDWIDTH":
+					v.Bandwidth, _ = strconv.Atoi(kv[1])
+				case "RESOLUTION":
+					v.Resolution = kv[1]
+				case "CODECS":
+					v.Codecs = strings.Trim(kv[1], `"`)
+				}
+			}
+			pending = &v
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		default:
+			if pending != nil {
+				pending.PlaylistURL = line
+				variants = append(variants, *pending)
+				pending = nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanning hls master playlist: %w", err)
+	}
+	return variants, nil
+}
+
+// splitHlsAttrs splits a comma-separated HLS attribute list while treating
+// commas inside double quotes (e.g. CODECS="avc1.64001f,mp4a.40.2") as part
+// of the value rather than a separator.
+func splitHlsAttrs(s string) []string {
+	var attrs []string
+	var quoted bool
+	start := 0
+	for i, r := range s {
+		switch r {
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				attrs = append(attrs, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	attrs = append(attrs, s[start:])
+	return attrs
+}
+
+func fetchHlsSegments(mediaPlaylistURL string) ([]string, error) {
+	resp, err := httpClient().Get(mediaPlaylistURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetching hls media playlist: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var segments []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		segments = append(segments, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanning hls media playlist: %w", err)
+	}
+	return segments, nil
+}
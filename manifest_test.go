@@ -0,0 +1,64 @@
+package youtube
+
+import "testing"
+
+func TestParseDashManifestTimeBasedTimeline(t *testing.T) {
+	const body = `<?xml version="1.0"?>
+<MPD>
+  <Period>
+    <AdaptationSet>
+      <Representation id="137" codecs="avc1.640028" bandwidth="123456" width="1920" height="1080">
+        <SegmentTemplate timescale="1000" initialization="init-$RepresentationID$.m4s" media="seg-$RepresentationID$-$Time$.m4s">
+          <SegmentTimeline>
+            <S t="0" d="2000" r="2"/>
+          </SegmentTimeline>
+        </SegmentTemplate>
+      </Representation>
+    </AdaptationSet>
+  </Period>
+</MPD>`
+
+	formats, err := parseDashManifest([]byte(body))
+	if err != nil {
+		t.Fatalf("parseDashManifest: %v", err)
+	}
+	if len(formats) != 1 {
+		t.Fatalf("got %d formats, want 1", len(formats))
+	}
+
+	f := formats[0]
+	if f.InitURL != "init-137.m4s" {
+		t.Errorf("InitURL = %q, want %q", f.InitURL, "init-137.m4s")
+	}
+
+	want := []string{
+		"seg-137-0.m4s",
+		"seg-137-2000.m4s",
+		"seg-137-4000.m4s",
+	}
+	if len(f.SegmentURLs_) != len(want) {
+		t.Fatalf("got %d segment urls, want %d: %v", len(f.SegmentURLs_), len(want), f.SegmentURLs_)
+	}
+	for i, w := range want {
+		if f.SegmentURLs_[i] != w {
+			t.Errorf("segment %d = %q, want %q", i, f.SegmentURLs_[i], w)
+		}
+	}
+}
+
+func TestSplitHlsAttrs(t *testing.T) {
+	got := splitHlsAttrs(`BANDWIDTH=1280000,RESOLUTION=1920x1080,CODECS="avc1.64001f,mp4a.40.2"`)
+	want := []string{
+		`BANDWIDTH=1280000`,
+		`RESOLUTION=1920x1080`,
+		`CODECS="avc1.64001f,mp4a.40.2"`,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d attrs, want %d: %v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i] != w {
+			t.Errorf("attr %d = %q, want %q", i, got[i], w)
+		}
+	}
+}
@@ -68,6 +68,19 @@ func getVideoType(uri string) (string, error) {
 	return "", errors.New("failed to parse id from URL")
 }
 
+// getVideoTypeID returns the raw ID or handle getVideoType would otherwise
+// embed into its resolved "/videos" URL, for callers that need the
+// identifier itself rather than a page to fetch.
+func getVideoTypeID(uri string) (string, error) {
+	for video := range videoTypeMap {
+		re := MatchOneOf(uri, videoTypeMap[video].Pattern)
+		if re != nil && len(re) >= 3 && len(re[2]) > 0 {
+			return re[2], nil
+		}
+	}
+	return "", errors.New("failed to parse id from URL")
+}
+
 type Playlist struct {
 	ID          string
 	Title       string
@@ -86,6 +99,34 @@ type PlaylistEntry struct {
 	Duration time.Duration
 }
 
+// GetPlaylistInfo fetches and parses the playlist page for urlOrID, which
+// may be a full playlist URL (one carrying a list= parameter) or a bare
+// playlist ID. Channel and user "videos" URLs are not accepted here; see
+// GetChannelVideos.
+func GetPlaylistInfo(urlOrID string) (*Playlist, error) {
+	id, err := extractPlaylistID(urlOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient().Get(fmt.Sprintf(playlistFetchURL, id))
+	if err != nil {
+		return nil, fmt.Errorf("fetching playlist page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := extractPlaylistJSON(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("extracting playlist JSON: %w", err)
+	}
+
+	p := &Playlist{ID: id}
+	if err := p.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
 func extractPlaylistID(url string) (string, error) {
 	if playlistIDRegex.Match([]byte(url)) {
 		return url, nil
@@ -145,11 +186,6 @@ func (p *Playlist) UnmarshalJSON(b []byte) (err error) {
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = fmt.Errorf("JSON parsing error: %v", r)
-		}
-	}()
 	p.Title = j.GetPath("metadata", "playlistMetadataRenderer", "title").MustString()
 	if p.Title == "" {
 		p.Title = j.GetPath("metadata", "channelMetadataRenderer", "title").MustString()
@@ -169,31 +205,41 @@ func (p *Playlist) UnmarshalJSON(b []byte) (err error) {
 		GetPath("tabRenderer", "content", "sectionListRenderer", "contents").GetIndex(0).
 		GetPath("itemSectionRenderer", "contents").GetIndex(0).
 		GetPath("playlistVideoListRenderer", "contents").MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSchemaChanged, err)
+	}
 
-	fmt.Printf("playlist %+v", p)
 	var vids []*videosJSONExtractor
 	if err := json.Unmarshal(vJSON, &vids); err != nil {
-		return err
+		return fmt.Errorf("%w: %v", ErrSchemaChanged, err)
 	}
 	if len(vids) == 0 {
 		vJSON, err = j.GetPath("contents", "twoColumnBrowseResultsRenderer", "tabs").GetIndex(1).
 			GetPath("tabRenderer", "content", "sectionListRenderer", "contents").GetIndex(0).
 			GetPath("itemSectionRenderer", "contents").GetIndex(0).
 			GetPath("gridRenderer", "items").MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrSchemaChanged, err)
+		}
 		if err := json.Unmarshal(vJSON, &vids); err != nil {
-			fmt.Printf("err %v", err)
-			return err
+			return fmt.Errorf("%w: %v", ErrSchemaChanged, err)
 		}
 	}
-	fmt.Println("vids ", vids)
+
 	p.Videos = make([]*PlaylistEntry, 0, len(vids))
 	for _, v := range vids {
-
-		if v.Renderer != nil || v.ChannelRenderer != nil {
-			fmt.Println("PlaylistEntry ", v.PlaylistEntry())
-			p.Videos = append(p.Videos, v.PlaylistEntry())
+		if v.Renderer == nil && v.ChannelRenderer == nil {
+			continue
 		}
-
+		entry, err := v.PlaylistEntry()
+		if err != nil {
+			logger().Printf("skipping malformed playlist entry: %v", err)
+			continue
+		}
+		p.Videos = append(p.Videos, entry)
+	}
+	if len(p.Videos) == 0 {
+		return ErrPlaylistEmpty
 	}
 	return nil
 }
@@ -219,36 +265,43 @@ type videosJSONExtractor struct {
 	} `json:"gridVideoRenderer"`
 }
 
-func (vje videosJSONExtractor) PlaylistEntry() *PlaylistEntry {
+// PlaylistEntry converts the raw JSON extraction into a PlaylistEntry. It
+// returns ErrParseDuration, wrapping the offending duration string, rather
+// than panicking, so a single malformed item doesn't abort the rest of the
+// playlist.
+func (vje videosJSONExtractor) PlaylistEntry() (*PlaylistEntry, error) {
 	if vje.Renderer != nil {
 		ds, err := strconv.Atoi(vje.Renderer.Duration)
 		if err != nil {
-			panic("invalid video duration: " + vje.Renderer.Duration)
+			return nil, fmt.Errorf("%w: %q: %v", ErrParseDuration, vje.Renderer.Duration, err)
 		}
 		return &PlaylistEntry{
 			ID:       vje.Renderer.ID,
 			Title:    vje.Renderer.Title.String(),
 			Author:   vje.Renderer.Author.String(),
 			Duration: time.Second * time.Duration(ds),
-		}
-	} else {
-		timeStr := vje.ChannelRenderer.ThumbnailOverlays[0].ThumbnailOverlayTimeStatusRenderer.Text.SimpleText
-		if strings.Count(timeStr, ":") == 1 {
-			timeStr = "0:" + timeStr
-		}
-		ds, err := time.Parse("3:4:5", timeStr)
-		if err != nil {
-			fmt.Print("invalid video duration: " + timeStr)
-		}
-		fmt.Println("ds ", ds, "time ", time.Time{})
-		return &PlaylistEntry{
-			ID:       vje.ChannelRenderer.ID,
-			Title:    vje.ChannelRenderer.Title.String(),
-			Author:   vje.ChannelRenderer.Author.String(),
-			Duration: ds.AddDate(1, 0, 0).Sub(time.Time{}),
-		}
+		}, nil
 	}
 
+	if len(vje.ChannelRenderer.ThumbnailOverlays) == 0 {
+		// Live broadcasts and premieres render a tile with no duration
+		// overlay at all.
+		return nil, fmt.Errorf("%w: channel renderer has no duration overlay", ErrParseDuration)
+	}
+	timeStr := vje.ChannelRenderer.ThumbnailOverlays[0].ThumbnailOverlayTimeStatusRenderer.Text.SimpleText
+	if strings.Count(timeStr, ":") == 1 {
+		timeStr = "0:" + timeStr
+	}
+	ds, err := time.Parse("3:4:5", timeStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", ErrParseDuration, timeStr, err)
+	}
+	return &PlaylistEntry{
+		ID:       vje.ChannelRenderer.ID,
+		Title:    vje.ChannelRenderer.Title.String(),
+		Author:   vje.ChannelRenderer.Author.String(),
+		Duration: ds.AddDate(1, 0, 0).Sub(time.Time{}),
+	}, nil
 }
 
 type withRuns struct {
@@ -0,0 +1,401 @@
+package youtube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	videoFetchURL  = "https://www.youtube.com/watch?v=%s&hl=en"
+	playerJSURLFmt = "https://www.youtube.com%s"
+)
+
+var (
+	videoIDRegex     = regexp.MustCompile(`^[a-zA-Z0-9_-]{11}$`)
+	videoInURLRegex  = regexp.MustCompile(`(?:v=|/)([a-zA-Z0-9_-]{11})(?:[&?/]|$)`)
+	playerResponseRe = regexp.MustCompile(`ytInitialPlayerResponse\s*=\s*({.*?})\s*;\s*(?:var\s+meta|</script>)`)
+	playerURLRegex   = regexp.MustCompile(`"(?:PLAYER_JS_URL|jsUrl)"\s*:\s*"([^"]+)"`)
+)
+
+// Video holds the metadata and stream formats resolved from a watch page.
+type Video struct {
+	ID            string
+	Title         string
+	Description   string
+	Author        string
+	DatePublished time.Time
+	Duration      time.Duration
+	Keywords      []string
+	Formats       FormatList
+	DashFormats   []DashFormat
+	HlsVariants   []HlsVariant
+	Captions      []CaptionTrack
+}
+
+// Format describes a single audio/video stream as advertised by YouTube.
+type Format struct {
+	Itag            int
+	MimeType        string
+	QualityLabel    string
+	Bitrate         int
+	Width           int
+	Height          int
+	FPS             int
+	AudioSampleRate int
+	ContentLength   int64
+	URL             string
+
+	// unresolvedSig and sigParam are only set while a signatureCipher has
+	// not yet been deciphered; resolveSignatures clears them once URL
+	// carries the final signed URL.
+	unresolvedSig string
+	sigParam      string
+}
+
+// FormatList is a collection of Format with convenience helpers.
+type FormatList []Format
+
+// Best returns the highest-bitrate format whose MIME type starts with
+// mimePrefix (pass "" to consider every format), preferring a progressive
+// stream (one that carries both audio and video) over an adaptive one.
+func (fl FormatList) Best(mimePrefix string) *Format {
+	var best *Format
+	for i := range fl {
+		f := &fl[i]
+		if mimePrefix != "" && !strings.HasPrefix(f.MimeType, mimePrefix) {
+			continue
+		}
+		progressive := f.Width > 0 && f.AudioSampleRate > 0
+		if best == nil {
+			best = f
+			continue
+		}
+		bestProgressive := best.Width > 0 && best.AudioSampleRate > 0
+		switch {
+		case progressive && !bestProgressive:
+			best = f
+		case progressive == bestProgressive && f.Bitrate > best.Bitrate:
+			best = f
+		}
+	}
+	return best
+}
+
+func extractVideoID(urlOrID string) (string, error) {
+	if videoIDRegex.MatchString(urlOrID) {
+		return urlOrID, nil
+	}
+	if idx := strings.Index(urlOrID, "youtu.be/"); idx >= 0 {
+		id := urlOrID[idx+len("youtu.be/"):]
+		id = strings.SplitN(id, "?", 2)[0]
+		if videoIDRegex.MatchString(id) {
+			return id, nil
+		}
+	}
+	if m := videoInURLRegex.FindStringSubmatch(urlOrID); m != nil {
+		return m[1], nil
+	}
+	return "", errors.New("failed to parse video id from URL")
+}
+
+// GetVideoInfo fetches and parses the watch page for urlOrID, which may be a
+// full https://www.youtube.com/watch?v= URL, a youtu.be short URL, or a bare
+// 11-character video ID.
+func GetVideoInfo(urlOrID string) (*Video, error) {
+	id, err := extractVideoID(urlOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient().Get(fmt.Sprintf(videoFetchURL, id))
+	if err != nil {
+		return nil, fmt.Errorf("fetching watch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return parseVideoPage(resp.Body, id)
+}
+
+func parseVideoPage(r io.Reader, id string) (*Video, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading watch page: %w", err)
+	}
+	page := string(body)
+
+	pr, err := extractPlayerResponse(page)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := newVideoFromPlayerResponse(id, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	if m := playerURLRegex.FindStringSubmatch(page); m != nil {
+		if err := resolveSignatures(m[1], v.Formats); err != nil {
+			logger().Printf("resolving signatures: %v", err)
+		}
+	}
+
+	if dashURL := pr.StreamingData.DashManifestURL; dashURL != "" {
+		formats, err := fetchDashFormats(dashURL)
+		if err != nil {
+			logger().Printf("fetching dash formats: %v", err)
+		} else {
+			v.DashFormats = formats
+		}
+	}
+
+	if hlsURL := pr.StreamingData.HlsManifestURL; hlsURL != "" {
+		variants, err := fetchHlsVariants(hlsURL)
+		if err != nil {
+			logger().Printf("fetching hls variants: %v", err)
+		} else {
+			v.HlsVariants = variants
+		}
+	}
+
+	return v, nil
+}
+
+func extractPlayerResponse(page string) (*playerResponseData, error) {
+	m := playerResponseRe.FindStringSubmatch(page)
+	if m == nil {
+		return nil, ErrSchemaChanged
+	}
+	var pr playerResponseData
+	if err := json.Unmarshal([]byte(m[1]), &pr); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSchemaChanged, err)
+	}
+	return &pr, nil
+}
+
+// playerResponseData mirrors the subset of ytInitialPlayerResponse /
+// player_response that GetVideoInfo cares about.
+type playerResponseData struct {
+	VideoDetails struct {
+		VideoID       string   `json:"videoId"`
+		Title         string   `json:"title"`
+		Author        string   `json:"author"`
+		ShortDesc     string   `json:"shortDescription"`
+		Keywords      []string `json:"keywords"`
+		LengthSeconds string   `json:"lengthSeconds"`
+	} `json:"videoDetails"`
+	Microformat struct {
+		PlayerMicroformatRenderer struct {
+			PublishDate string `json:"publishDate"`
+		} `json:"playerMicroformatRenderer"`
+	} `json:"microformat"`
+	StreamingData struct {
+		Formats         []streamFormat `json:"formats"`
+		AdaptiveFormats []streamFormat `json:"adaptiveFormats"`
+		DashManifestURL string         `json:"dashManifestUrl"`
+		HlsManifestURL  string         `json:"hlsManifestUrl"`
+	} `json:"streamingData"`
+	Captions struct {
+		PlayerCaptionsTracklistRenderer struct {
+			CaptionTracks []captionTrackJSON `json:"captionTracks"`
+		} `json:"playerCaptionsTracklistRenderer"`
+	} `json:"captions"`
+}
+
+type streamFormat struct {
+	Itag            int    `json:"itag"`
+	MimeType        string `json:"mimeType"`
+	Bitrate         int    `json:"bitrate"`
+	Width           int    `json:"width"`
+	Height          int    `json:"height"`
+	ContentLength   string `json:"contentLength"`
+	QualityLabel    string `json:"qualityLabel"`
+	FPS             int    `json:"fps"`
+	AudioSampleRate string `json:"audioSampleRate"`
+	URL             string `json:"url"`
+	SignatureCipher string `json:"signatureCipher"`
+	Cipher          string `json:"cipher"`
+}
+
+func newVideoFromPlayerResponse(id string, pr *playerResponseData) (*Video, error) {
+	vd := pr.VideoDetails
+	if vd.VideoID == "" && id == "" {
+		return nil, ErrSchemaChanged
+	}
+
+	durSeconds, _ := strconv.Atoi(vd.LengthSeconds)
+	v := &Video{
+		ID:          vd.VideoID,
+		Title:       vd.Title,
+		Description: vd.ShortDesc,
+		Author:      vd.Author,
+		Keywords:    vd.Keywords,
+		Duration:    time.Duration(durSeconds) * time.Second,
+	}
+	if v.ID == "" {
+		v.ID = id
+	}
+
+	if pub := pr.Microformat.PlayerMicroformatRenderer.PublishDate; pub != "" {
+		t, err := time.Parse("2006-01-02", pub)
+		if err != nil {
+			logger().Printf("parsing publish date %q: %v", pub, err)
+		} else {
+			v.DatePublished = t
+		}
+	}
+
+	all := append(append([]streamFormat{}, pr.StreamingData.Formats...), pr.StreamingData.AdaptiveFormats...)
+	v.Formats = make(FormatList, 0, len(all))
+	for _, sf := range all {
+		f, err := sf.toFormat()
+		if err != nil {
+			logger().Printf("skipping format itag=%d: %v", sf.Itag, err)
+			continue
+		}
+		v.Formats = append(v.Formats, f)
+	}
+	sort.Slice(v.Formats, func(i, j int) bool { return v.Formats[i].Bitrate > v.Formats[j].Bitrate })
+
+	tracks := pr.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks
+	v.Captions = make([]CaptionTrack, 0, len(tracks))
+	for _, t := range tracks {
+		v.Captions = append(v.Captions, t.toCaptionTrack())
+	}
+
+	return v, nil
+}
+
+func (sf streamFormat) toFormat() (Format, error) {
+	contentLength, _ := strconv.ParseInt(sf.ContentLength, 10, 64)
+	sampleRate, _ := strconv.Atoi(sf.AudioSampleRate)
+
+	f := Format{
+		Itag:            sf.Itag,
+		MimeType:        sf.MimeType,
+		QualityLabel:    sf.QualityLabel,
+		Bitrate:         sf.Bitrate,
+		Width:           sf.Width,
+		Height:          sf.Height,
+		FPS:             sf.FPS,
+		AudioSampleRate: sampleRate,
+		ContentLength:   contentLength,
+		URL:             sf.URL,
+	}
+
+	if f.URL != "" {
+		return f, nil
+	}
+
+	cipher := sf.SignatureCipher
+	if cipher == "" {
+		cipher = sf.Cipher
+	}
+	if cipher == "" {
+		return f, fmt.Errorf("%w: itag %d has no url or cipher", ErrSignatureCipher, sf.Itag)
+	}
+
+	values, err := url.ParseQuery(cipher)
+	if err != nil {
+		return f, fmt.Errorf("%w: %v", ErrSignatureCipher, err)
+	}
+	f.URL = values.Get("url")
+	f.unresolvedSig = values.Get("s")
+	f.sigParam = values.Get("sp")
+	if f.sigParam == "" {
+		f.sigParam = "sig"
+	}
+	return f, nil
+}
+
+// resolveSignatures downloads (or reuses the cached decipher ops for) the
+// player JS at jsURL and rewrites, in place, every format still carrying an
+// unresolved signature.
+func resolveSignatures(jsURL string, formats FormatList) error {
+	needsResolving := false
+	for i := range formats {
+		if formats[i].unresolvedSig != "" {
+			needsResolving = true
+			break
+		}
+	}
+	if !needsResolving {
+		return nil
+	}
+
+	ops, err := getDecipherOps(jsURL)
+	if err != nil {
+		return err
+	}
+
+	for i := range formats {
+		f := &formats[i]
+		if f.unresolvedSig == "" {
+			continue
+		}
+		sig := ops.apply(f.unresolvedSig)
+		u, err := url.Parse(f.URL)
+		if err != nil {
+			continue
+		}
+		q := u.Query()
+		q.Set(f.sigParam, sig)
+		u.RawQuery = q.Encode()
+		f.URL = u.String()
+		f.unresolvedSig = ""
+		f.sigParam = ""
+	}
+	return nil
+}
+
+// decipherCache caches parsed decipher operations per player JS URL, since
+// resolving one requires both a network fetch and a regex scan of a
+// multi-megabyte script.
+var decipherCache = struct {
+	sync.Mutex
+	ops map[string]*decipherOps
+}{ops: map[string]*decipherOps{}}
+
+func getDecipherOps(jsURL string) (*decipherOps, error) {
+	decipherCache.Lock()
+	if ops, ok := decipherCache.ops[jsURL]; ok {
+		decipherCache.Unlock()
+		return ops, nil
+	}
+	decipherCache.Unlock()
+
+	full := jsURL
+	if strings.HasPrefix(jsURL, "/") {
+		full = fmt.Sprintf(playerJSURLFmt, jsURL)
+	}
+
+	resp, err := httpClient().Get(full)
+	if err != nil {
+		return nil, fmt.Errorf("%w: fetching player js: %v", ErrSignatureCipher, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading player js: %v", ErrSignatureCipher, err)
+	}
+
+	ops, err := parseDecipherOps(string(body))
+	if err != nil {
+		return nil, err
+	}
+
+	decipherCache.Lock()
+	decipherCache.ops[jsURL] = ops
+	decipherCache.Unlock()
+
+	return ops, nil
+}